@@ -0,0 +1,102 @@
+// Command parity runs the Go/Rust output-parity check outside of `go test`
+// and emits a JSON report suitable for CI.
+//
+// Run from benches/compare/go (the directory holding this module's go.mod):
+//
+//	HTML2MD_RS_BIN=../../../target/release/html2markdown-rs \
+//	    go run ./cmd/parity -fixtures ../../fixtures
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/nchapman/html2markdown-rs/benches/compare/go/paritytest"
+)
+
+// report is the top-level JSON document written to -out.
+type report struct {
+	Plugins  []string            `json:"plugins"`
+	RustBin  string              `json:"rust_bin"`
+	Results  []paritytest.Result `json:"results"`
+	Mismatch bool                `json:"mismatch"`
+}
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, "parity:", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	var (
+		rustBin         = flag.String("rust-bin", os.Getenv(paritytest.RustBinEnvVar), "path to the html2markdown-rs binary")
+		fixturesDir     = flag.String("fixtures", "benches/fixtures", "directory containing fixture .html files")
+		outPath         = flag.String("out", "", "write JSON report here instead of stdout")
+		enableBase      = flag.Bool("base", true, "enable the base plugin")
+		enableCommon    = flag.Bool("commonmark", true, "enable the commonmark plugin")
+		enableTables    = flag.Bool("gfm-tables", false, "enable the GFM tables plugin")
+		enableStrikethr = flag.Bool("gfm-strikethrough", false, "enable the GFM strikethrough plugin")
+	)
+	flag.Parse()
+
+	if *rustBin == "" {
+		return fmt.Errorf("no Rust binary: set -rust-bin or %s", paritytest.RustBinEnvVar)
+	}
+
+	plugins := paritytest.PluginSet{
+		Base:             *enableBase,
+		Commonmark:       *enableCommon,
+		GFMTables:        *enableTables,
+		GFMStrikethrough: *enableStrikethr,
+	}
+
+	fixtures, err := paritytest.LoadFixtures(*fixturesDir)
+	if err != nil {
+		return err
+	}
+
+	rep := report{Plugins: plugins.Names(), RustBin: *rustBin}
+	for _, name := range paritytest.FixtureNames {
+		html := fixtures[name]
+
+		goOut, err := paritytest.ConvertGo(plugins, html)
+		if err != nil {
+			return fmt.Errorf("convert %q (go): %w", name, err)
+		}
+		rustOut, err := paritytest.ConvertRust(*rustBin, plugins, html)
+		if err != nil {
+			return fmt.Errorf("convert %q (rust): %w", name, err)
+		}
+
+		result := paritytest.Compare(name, goOut, rustOut)
+		if !result.Equal {
+			rep.Mismatch = true
+		}
+		rep.Results = append(rep.Results, result)
+	}
+
+	out := os.Stdout
+	if *outPath != "" {
+		f, err := os.Create(*outPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(rep); err != nil {
+		return err
+	}
+
+	if rep.Mismatch {
+		os.Exit(1)
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+// Benchmark roundtrip fidelity: HTML -> Markdown (via converter.ConvertString)
+// -> HTML (via goldmark, the canonical CommonMark/GFM renderer used by the
+// Hugo/website toolchain) -> diffed against a DOM-normalized form of the
+// original fixture.
+//
+// This complements BenchmarkConvert in bench_test.go: that benchmark only
+// measures conversion speed, so behavioral drift in the Rust port (or in the
+// Go reference itself) doesn't show up anywhere. Run alongside it with:
+//
+//	go test -bench=. -benchmem -benchtime=5s
+//
+// Fixtures that no longer roundtrip cleanly don't fail the benchmark; the
+// first few structural differences are dumped via b.Log so regressions are
+// visible without breaking CI on benign reformatting.
+package bench
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"golang.org/x/net/html"
+)
+
+// maxLoggedDiffs caps how many structural differences BenchmarkConvertFidelity
+// logs per fixture, so a badly-drifted fixture doesn't flood test output.
+const maxLoggedDiffs = 10
+
+// attrsKeptInMarkdown lists the only HTML attributes that survive a
+// Markdown roundtrip (e.g. <img alt> and <a href>/<a title>). Everything
+// else (class, id, style, data-*, ...) has no Markdown representation and
+// must be dropped before comparing trees.
+var attrsKeptInMarkdown = map[string]bool{
+	"href":  true,
+	"title": true,
+	"alt":   true,
+	"src":   true,
+}
+
+// newGoldmark returns the goldmark instance used to re-render converted
+// Markdown back to HTML, with GFM enabled to match tables/strikethrough
+// produced by the commonmark/GFM plugins.
+func newGoldmark() goldmark.Markdown {
+	return goldmark.New(goldmark.WithExtensions(extension.GFM))
+}
+
+// normalizeHTML parses html, drops attributes with no Markdown
+// representation, sorts the remaining attributes, collapses run of
+// whitespace in text nodes, and re-serializes the result so that two
+// semantically equivalent documents compare equal byte-for-byte.
+func normalizeHTML(src string) (string, error) {
+	doc, err := html.Parse(strings.NewReader(src))
+	if err != nil {
+		return "", err
+	}
+	normalizeNode(doc)
+
+	var buf bytes.Buffer
+	if err := html.Render(&buf, doc); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func normalizeNode(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		n.Data = collapseWhitespace(n.Data)
+	case html.ElementNode:
+		n.Attr = filterAndSortAttrs(n.Attr)
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		normalizeNode(c)
+	}
+}
+
+func collapseWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func filterAndSortAttrs(attrs []html.Attribute) []html.Attribute {
+	kept := make([]html.Attribute, 0, len(attrs))
+	for _, a := range attrs {
+		if attrsKeptInMarkdown[a.Key] {
+			kept = append(kept, a)
+		}
+	}
+	sort.Slice(kept, func(i, j int) bool { return kept[i].Key < kept[j].Key })
+	return kept
+}
+
+// elementCounts walks an HTML tree and counts element nodes by tag name.
+func elementCounts(src string) (map[string]int, error) {
+	doc, err := html.Parse(strings.NewReader(src))
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int)
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			counts[n.Data]++
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+	return counts, nil
+}
+
+// tagDiff is a single tag's element-count mismatch between the original
+// fixture and the Markdown-roundtripped version.
+type tagDiff struct {
+	tag     string
+	missing int // present in the original, absent after roundtrip
+	extra   int // present after roundtrip, absent from the original
+}
+
+// diffElementCounts compares per-tag element counts and returns the tags
+// that diverge, sorted by tag name for deterministic b.Log output.
+func diffElementCounts(orig, roundtrip map[string]int) []tagDiff {
+	tags := make(map[string]struct{}, len(orig)+len(roundtrip))
+	for tag := range orig {
+		tags[tag] = struct{}{}
+	}
+	for tag := range roundtrip {
+		tags[tag] = struct{}{}
+	}
+
+	var diffs []tagDiff
+	for tag := range tags {
+		o, r := orig[tag], roundtrip[tag]
+		if o == r {
+			continue
+		}
+		d := tagDiff{tag: tag}
+		if o > r {
+			d.missing = o - r
+		} else {
+			d.extra = r - o
+		}
+		diffs = append(diffs, d)
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].tag < diffs[j].tag })
+	return diffs
+}
+
+// BenchmarkConvertFidelity times the HTML->Markdown->HTML roundtrip for each
+// fixture and, once per fixture, logs a structural-diff score against the
+// normalized original so correctness regressions show up next to the
+// performance numbers.
+func BenchmarkConvertFidelity(b *testing.B) {
+	fixtures := loadFixtures(b)
+	conv := newConverter()
+	gm := newGoldmark()
+
+	for _, name := range fixtureNames {
+		htmlSrc := fixtures[name]
+		b.Run(name, func(b *testing.B) {
+			b.SetBytes(int64(len(htmlSrc)))
+
+			var rerendered string
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				md, err := conv.ConvertString(htmlSrc)
+				if err != nil {
+					b.Fatal(err)
+				}
+				var out bytes.Buffer
+				if err := gm.Convert([]byte(md), &out); err != nil {
+					b.Fatal(err)
+				}
+				rerendered = out.String()
+			}
+			b.StopTimer()
+
+			origNorm, err := normalizeHTML(htmlSrc)
+			if err != nil {
+				b.Fatalf("normalize original: %v", err)
+			}
+			reNorm, err := normalizeHTML(rerendered)
+			if err != nil {
+				b.Fatalf("normalize roundtrip: %v", err)
+			}
+			if origNorm == reNorm {
+				return
+			}
+
+			origCounts, err := elementCounts(htmlSrc)
+			if err != nil {
+				b.Fatalf("count original elements: %v", err)
+			}
+			reCounts, err := elementCounts(rerendered)
+			if err != nil {
+				b.Fatalf("count roundtrip elements: %v", err)
+			}
+
+			diffs := diffElementCounts(origCounts, reCounts)
+			b.Logf("%s: roundtrip diverges from original, %d tag(s) affected", name, len(diffs))
+			for i, d := range diffs {
+				if i >= maxLoggedDiffs {
+					b.Logf("  ... %d more", len(diffs)-maxLoggedDiffs)
+					break
+				}
+				b.Logf("  <%s>: missing=%d extra=%d", d.tag, d.missing, d.extra)
+			}
+		})
+	}
+}
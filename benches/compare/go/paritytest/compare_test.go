@@ -0,0 +1,113 @@
+package paritytest
+
+import "testing"
+
+// TestCompareEqual checks the fast path when Go and Rust output match
+// exactly, with no dependency on the Rust binary.
+func TestCompareEqual(t *testing.T) {
+	result := Compare("fixture", "# Title\n\nbody", "# Title\n\nbody")
+	if !result.Equal {
+		t.Fatalf("Equal = false, want true")
+	}
+	if result.FirstDivergingByte != -1 {
+		t.Errorf("FirstDivergingByte = %d, want -1", result.FirstDivergingByte)
+	}
+	if result.LinesAdded != 0 || result.LinesRemoved != 0 {
+		t.Errorf("LinesAdded/LinesRemoved = %d/%d, want 0/0", result.LinesAdded, result.LinesRemoved)
+	}
+	if result.Diff != "" {
+		t.Errorf("Diff = %q, want empty", result.Diff)
+	}
+}
+
+// TestCompareDivergent checks the line-diff path with a single changed
+// line among shared context.
+func TestCompareDivergent(t *testing.T) {
+	goOut := "# Title\n\nfoo\n\nshared"
+	rustOut := "# Title\n\nbar\n\nshared"
+
+	result := Compare("fixture", goOut, rustOut)
+	if result.Equal {
+		t.Fatal("Equal = true, want false")
+	}
+	if result.LinesAdded != 1 || result.LinesRemoved != 1 {
+		t.Errorf("LinesAdded/LinesRemoved = %d/%d, want 1/1", result.LinesAdded, result.LinesRemoved)
+	}
+	wantDiff := " # Title\n \n-foo\n+bar\n \n shared\n"
+	if result.Diff != wantDiff {
+		t.Errorf("Diff = %q, want %q", result.Diff, wantDiff)
+	}
+	if result.WhitespaceOnly {
+		t.Error("WhitespaceOnly = true, want false")
+	}
+}
+
+// TestCompareWhitespaceOnly checks that differences that collapse to the
+// same content under whitespace normalization are flagged as such.
+func TestCompareWhitespaceOnly(t *testing.T) {
+	result := Compare("fixture", "foo   bar", "foo bar")
+	if result.Equal {
+		t.Fatal("Equal = true, want false (byte-for-byte differs)")
+	}
+	if !result.WhitespaceOnly {
+		t.Error("WhitespaceOnly = false, want true")
+	}
+}
+
+func TestFirstDivergingByte(t *testing.T) {
+	cases := []struct {
+		name     string
+		a, b     string
+		wantByte int
+	}{
+		{"identical", "abc", "abc", -1},
+		{"diverges at start", "abc", "xbc", 0},
+		{"diverges in middle", "abc", "abx", 2},
+		{"b is a prefix of a", "abc", "ab", 2},
+		{"a is a prefix of b", "ab", "abc", 2},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := firstDivergingByte(c.a, c.b); got != c.wantByte {
+				t.Errorf("firstDivergingByte(%q, %q) = %d, want %d", c.a, c.b, got, c.wantByte)
+			}
+		})
+	}
+}
+
+func TestLineDiff(t *testing.T) {
+	added, removed, diff := lineDiff("a\nb\nc", "a\nx\nc")
+	if added != 1 || removed != 1 {
+		t.Errorf("added/removed = %d/%d, want 1/1", added, removed)
+	}
+	want := " a\n-b\n+x\n c\n"
+	if diff != want {
+		t.Errorf("diff = %q, want %q", diff, want)
+	}
+}
+
+func TestLongestCommonSubsequence(t *testing.T) {
+	cases := []struct {
+		name string
+		a, b []string
+		want []string
+	}{
+		{"identical", []string{"a", "b", "c"}, []string{"a", "b", "c"}, []string{"a", "b", "c"}},
+		{"disjoint", []string{"a", "b"}, []string{"x", "y"}, nil},
+		{"middle removed", []string{"a", "b", "c"}, []string{"a", "c"}, []string{"a", "c"}},
+		{"middle added", []string{"a", "c"}, []string{"a", "b", "c"}, []string{"a", "c"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := longestCommonSubsequence(c.a, c.b)
+			if len(got) != len(c.want) {
+				t.Fatalf("longestCommonSubsequence(%v, %v) = %v, want %v", c.a, c.b, got, c.want)
+			}
+			for i := range got {
+				if got[i] != c.want[i] {
+					t.Fatalf("longestCommonSubsequence(%v, %v) = %v, want %v", c.a, c.b, got, c.want)
+				}
+			}
+		})
+	}
+}
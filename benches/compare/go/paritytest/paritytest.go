@@ -0,0 +1,244 @@
+// Package paritytest compares the output of the Rust html2markdown-rs binary
+// against the Go reference implementation (JohannesKaufmann/html-to-markdown)
+// for the same set of fixtures, so behavioral drift between the two
+// implementations shows up as a diff instead of going unnoticed.
+//
+// The Rust binary is expected to read HTML on stdin, write Markdown to
+// stdout, and accept a `--plugins=<comma-separated list>` flag naming which
+// of base, commonmark, gfm-tables, gfm-strikethrough to enable, mirroring
+// the PluginSet below.
+package paritytest
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/base"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/commonmark"
+)
+
+// RustBinEnvVar is the environment variable pointing at the
+// html2markdown-rs binary under test.
+const RustBinEnvVar = "HTML2MD_RS_BIN"
+
+// FixtureNames are the fixtures both implementations are compared against.
+var FixtureNames = []string{"article", "table", "lists", "code", "large"}
+
+// PluginSet selects which conversion plugins are active on both sides of the
+// comparison, so parity can be scoped to a feature subset. GFMTables and
+// GFMStrikethrough are placeholders for plugins that don't exist yet on the
+// Go side; enabling them today returns an error from GoPlugins.
+type PluginSet struct {
+	Base             bool
+	Commonmark       bool
+	GFMTables        bool
+	GFMStrikethrough bool
+}
+
+// DefaultPluginSet enables the same plugins newConverter uses in bench_test.go.
+func DefaultPluginSet() PluginSet {
+	return PluginSet{Base: true, Commonmark: true}
+}
+
+// Names returns the enabled plugins under the names the Rust binary's
+// --plugins flag expects; ConvertRust joins them with commas.
+func (p PluginSet) Names() []string {
+	var names []string
+	if p.Base {
+		names = append(names, "base")
+	}
+	if p.Commonmark {
+		names = append(names, "commonmark")
+	}
+	if p.GFMTables {
+		names = append(names, "gfm-tables")
+	}
+	if p.GFMStrikethrough {
+		names = append(names, "gfm-strikethrough")
+	}
+	return names
+}
+
+// GoPlugins builds the converter.Plugin list for p, the Go-side equivalent
+// of Names.
+func (p PluginSet) GoPlugins() ([]converter.Plugin, error) {
+	var plugins []converter.Plugin
+	if p.Base {
+		plugins = append(plugins, base.NewBasePlugin())
+	}
+	if p.Commonmark {
+		plugins = append(plugins, commonmark.NewCommonmarkPlugin())
+	}
+	if p.GFMTables {
+		return nil, fmt.Errorf("paritytest: gfm-tables plugin not yet implemented on the Go side")
+	}
+	if p.GFMStrikethrough {
+		return nil, fmt.Errorf("paritytest: gfm-strikethrough plugin not yet implemented on the Go side")
+	}
+	return plugins, nil
+}
+
+// LoadFixtures reads HTML content for each name in FixtureNames from dir.
+func LoadFixtures(dir string) (map[string]string, error) {
+	fixtures := make(map[string]string, len(FixtureNames))
+	for _, name := range FixtureNames {
+		data, err := os.ReadFile(filepath.Join(dir, name+".html"))
+		if err != nil {
+			return nil, fmt.Errorf("load fixture %q: %w", name, err)
+		}
+		fixtures[name] = string(data)
+	}
+	return fixtures, nil
+}
+
+// ConvertGo runs the Go reference converter over html using the plugins in p.
+func ConvertGo(p PluginSet, html string) (string, error) {
+	plugins, err := p.GoPlugins()
+	if err != nil {
+		return "", err
+	}
+	conv := converter.NewConverter(converter.WithPlugins(plugins...))
+	return conv.ConvertString(html)
+}
+
+// ConvertRust shells out to rustBin, feeding html on stdin and returning the
+// Markdown written to stdout.
+func ConvertRust(rustBin string, p PluginSet, html string) (string, error) {
+	args := []string{"--plugins=" + strings.Join(p.Names(), ",")}
+	cmd := exec.Command(rustBin, args...)
+	cmd.Stdin = strings.NewReader(html)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("run %s: %w (stderr: %s)", rustBin, err, stderr.String())
+	}
+	return stdout.String(), nil
+}
+
+// Result summarizes how the Go and Rust output diverge for a single fixture.
+type Result struct {
+	Fixture            string `json:"fixture"`
+	Equal              bool   `json:"equal"`
+	LinesAdded         int    `json:"lines_added"`
+	LinesRemoved       int    `json:"lines_removed"`
+	FirstDivergingByte int    `json:"first_diverging_byte"` // -1 when Equal
+	WhitespaceOnly     bool   `json:"whitespace_only"`
+	Diff               string `json:"diff,omitempty"`
+}
+
+// Compare diffs the Go and Rust Markdown output for fixture and reports a
+// unified-style diff plus summary statistics.
+func Compare(fixture, goOut, rustOut string) Result {
+	if goOut == rustOut {
+		return Result{Fixture: fixture, Equal: true, FirstDivergingByte: -1}
+	}
+
+	added, removed, diff := lineDiff(goOut, rustOut)
+	return Result{
+		Fixture:            fixture,
+		Equal:              false,
+		LinesAdded:         added,
+		LinesRemoved:       removed,
+		FirstDivergingByte: firstDivergingByte(goOut, rustOut),
+		WhitespaceOnly:     collapseWhitespace(goOut) == collapseWhitespace(rustOut),
+		Diff:               diff,
+	}
+}
+
+func firstDivergingByte(a, b string) int {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	if len(a) != len(b) {
+		return n
+	}
+	return -1
+}
+
+func collapseWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// lineDiff produces a minimal unified-style line diff between the Go output
+// (the "want" side, prefixed "-" when removed) and the Rust output (the
+// "got" side, prefixed "+" when added), via a longest-common-subsequence
+// alignment. It also returns the added/removed line counts.
+func lineDiff(goOut, rustOut string) (added, removed int, diff string) {
+	goLines := strings.Split(goOut, "\n")
+	rustLines := strings.Split(rustOut, "\n")
+
+	lcs := longestCommonSubsequence(goLines, rustLines)
+
+	var b strings.Builder
+	gi, ri, li := 0, 0, 0
+	flush := func() {
+		for gi < len(goLines) && (li >= len(lcs) || goLines[gi] != lcs[li]) {
+			fmt.Fprintf(&b, "-%s\n", goLines[gi])
+			removed++
+			gi++
+		}
+		for ri < len(rustLines) && (li >= len(lcs) || rustLines[ri] != lcs[li]) {
+			fmt.Fprintf(&b, "+%s\n", rustLines[ri])
+			added++
+			ri++
+		}
+	}
+	for li < len(lcs) {
+		flush()
+		fmt.Fprintf(&b, " %s\n", lcs[li])
+		gi++
+		ri++
+		li++
+	}
+	flush()
+
+	return added, removed, b.String()
+}
+
+// longestCommonSubsequence returns the LCS of a and b by line content.
+func longestCommonSubsequence(a, b []string) []string {
+	n, m := len(a), len(b)
+	dp := make([][]int, n+1)
+	for i := range dp {
+		dp[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				dp[i][j] = dp[i+1][j+1] + 1
+			} else if dp[i+1][j] >= dp[i][j+1] {
+				dp[i][j] = dp[i+1][j]
+			} else {
+				dp[i][j] = dp[i][j+1]
+			}
+		}
+	}
+
+	var lcs []string
+	for i, j := 0, 0; i < n && j < m; {
+		switch {
+		case a[i] == b[j]:
+			lcs = append(lcs, a[i])
+			i++
+			j++
+		case dp[i+1][j] >= dp[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return lcs
+}
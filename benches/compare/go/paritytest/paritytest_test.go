@@ -0,0 +1,52 @@
+package paritytest
+
+import (
+	"os"
+	"testing"
+)
+
+// fixturesDir is ../../fixtures relative to bench_test.go's package
+// (benches/compare/go); paritytest sits one directory deeper.
+const fixturesDir = "../../../fixtures"
+
+// TestParity compares Go and Rust output for every fixture under the
+// default plugin set. It's skipped when HTML2MD_RS_BIN isn't set or doesn't
+// point at a runnable binary, since the Rust build isn't available in every
+// environment that runs `go test`.
+func TestParity(t *testing.T) {
+	rustBin := os.Getenv(RustBinEnvVar)
+	if rustBin == "" {
+		t.Skipf("%s not set; skipping Rust/Go parity check", RustBinEnvVar)
+	}
+	if _, err := os.Stat(rustBin); err != nil {
+		t.Skipf("%s=%s not found: %v", RustBinEnvVar, rustBin, err)
+	}
+
+	fixtures, err := LoadFixtures(fixturesDir)
+	if err != nil {
+		t.Fatalf("load fixtures: %v", err)
+	}
+
+	plugins := DefaultPluginSet()
+	for _, name := range FixtureNames {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			html := fixtures[name]
+
+			goOut, err := ConvertGo(plugins, html)
+			if err != nil {
+				t.Fatalf("convert (go): %v", err)
+			}
+			rustOut, err := ConvertRust(rustBin, plugins, html)
+			if err != nil {
+				t.Fatalf("convert (rust): %v", err)
+			}
+
+			result := Compare(name, goOut, rustOut)
+			if !result.Equal {
+				t.Errorf("go and rust output diverge for %q (first diverging byte %d, whitespace-only=%v):\n%s",
+					name, result.FirstDivergingByte, result.WhitespaceOnly, result.Diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Benchmark the streaming conversion path alongside the existing
+// ConvertString benchmark in bench_test.go, to quantify the allocation win
+// of flushing completed top-level blocks as they close instead of
+// buffering the whole document twice (once as input, once as output) the
+// way ConvertString does.
+//
+// The streaming entry point lives in ./streamconv: the vendored
+// JohannesKaufmann/html-to-markdown converter only exposes ConvertString,
+// and this harness can't add methods to that external package, so
+// streamconv.StreamConverter wraps a *converter.Converter instead and does
+// the incremental tokenizing itself. See streamconv.FullDocumentPlugin for
+// the NeedsFullDocument opt-out invariant.
+package bench
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/base"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/commonmark"
+
+	"github.com/nchapman/html2markdown-rs/benches/compare/go/streamconv"
+)
+
+// newStreamConverter builds a StreamConverter over the same plugin set
+// newConverter uses, so the streaming and buffered benchmarks are
+// comparing the same conversion behavior.
+func newStreamConverter() *streamconv.StreamConverter {
+	basePlugin := base.NewBasePlugin()
+	commonmarkPlugin := commonmark.NewCommonmarkPlugin()
+	conv := newConverter()
+	return streamconv.New(conv, basePlugin, commonmarkPlugin)
+}
+
+// BenchmarkConvertStream mirrors BenchmarkConvert but runs each fixture
+// through StreamConverter.ConvertStream instead of ConvertString, so the
+// memory win over the buffered path is visible next to the existing
+// numbers.
+func BenchmarkConvertStream(b *testing.B) {
+	fixtures := loadFixtures(b)
+	stream := newStreamConverter()
+
+	for _, name := range fixtureNames {
+		html := fixtures[name]
+		b.Run("stream/"+name, func(b *testing.B) {
+			b.SetBytes(int64(len(html)))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := stream.ConvertStream(io.Discard, bytes.NewReader([]byte(html))); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
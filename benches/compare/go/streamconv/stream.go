@@ -0,0 +1,234 @@
+// Package streamconv adds a streaming conversion entry point on top of the
+// vendored JohannesKaufmann/html-to-markdown converter, which otherwise only
+// exposes ConvertString: the whole document has to sit in memory twice
+// (once as the input string, once as the output string).
+//
+// StreamConverter.ConvertStream instead parses the input incrementally with
+// golang.org/x/net/html's tokenizer and flushes each completed top-level
+// block (paragraph, heading, blockquote, pre/code block, list, table, ...)
+// to the writer as soon as its subtree closes, so peak memory scales with
+// the largest single block rather than the whole document.
+//
+// Lists and tables are flushed as a whole once their closing tag is seen,
+// not row-by-row or item-by-item: a GFM table needs every row in hand to
+// emit the header separator, so splitting further would produce invalid
+// Markdown. That still bounds memory to the size of one list/table instead
+// of the full document.
+//
+// Content between block boundaries (inline markup, bare text, scripts,
+// styles, ...) is accumulated into a single run and flushed together right
+// before the next block starts, so an anchor's open tag, text, and close
+// tag are always converted in one fragment rather than split apart.
+//
+// Only blocks at the outermost nesting level are flushed on their own. A
+// block inside any other element (an <html>, <body> or <div> wrapper, for
+// example) is buffered with that element as part of the loose run, so a
+// document wrapped that way is held in memory whole and converted in one
+// piece.
+package streamconv
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"golang.org/x/net/html"
+)
+
+// FullDocumentPlugin is an opt-out hook for plugins that need to see the
+// whole document to do their job (e.g. collecting footnote references
+// before emitting the definitions block). When any plugin passed to
+// New implements this and NeedsFullDocument returns true,
+// ConvertStream falls back to buffering the whole input instead of
+// streaming it.
+type FullDocumentPlugin interface {
+	NeedsFullDocument() bool
+}
+
+// blockTags are the top-level element names ConvertStream treats as
+// independently flushable units.
+var blockTags = map[string]bool{
+	"p": true, "pre": true, "blockquote": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"ul": true, "ol": true, "table": true, "hr": true,
+}
+
+// voidElements never receive a matching end tag, so they don't open a new
+// nesting level while tracking a block's subtree.
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true,
+	"hr": true, "img": true, "input": true, "link": true, "meta": true,
+	"param": true, "source": true, "track": true, "wbr": true,
+}
+
+// StreamConverter wraps a *converter.Converter to add ConvertStream. The
+// plugins passed to New must be the same ones the Converter was built with;
+// StreamConverter inspects them to decide whether streaming is safe.
+type StreamConverter struct {
+	conv    *converter.Converter
+	plugins []converter.Plugin
+}
+
+// New wraps conv, which must already have plugins registered via
+// converter.WithPlugins(plugins...), for streaming conversion.
+func New(conv *converter.Converter, plugins ...converter.Plugin) *StreamConverter {
+	return &StreamConverter{conv: conv, plugins: plugins}
+}
+
+// needsFullDocument reports whether any registered plugin opted out of
+// streaming via FullDocumentPlugin.
+func (s *StreamConverter) needsFullDocument() bool {
+	for _, p := range s.plugins {
+		if fd, ok := p.(FullDocumentPlugin); ok && fd.NeedsFullDocument() {
+			return true
+		}
+	}
+	return false
+}
+
+// ConvertStream reads HTML from r and writes the converted Markdown to w,
+// flushing completed top-level blocks as they close. If a registered
+// plugin requires whole-document context, it falls back to buffering r
+// entirely before converting.
+func (s *StreamConverter) ConvertStream(w io.Writer, r io.Reader) error {
+	if s.needsFullDocument() {
+		return s.convertBuffered(w, r)
+	}
+	return s.convertIncremental(w, r)
+}
+
+func (s *StreamConverter) convertBuffered(w io.Writer, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	md, err := s.conv.ConvertString(string(data))
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, md)
+	return err
+}
+
+// convertIncremental walks the token stream, buffering only the content
+// between block boundaries, and converts+flushes it as soon as that
+// boundary is reached.
+//
+// Content outside blockTags (inline markup, bare text, <script>/<style>,
+// ...) isn't flushed token-by-token: that would split open/close tag pairs
+// across separate ConvertString calls, losing link/emphasis markup and
+// feeding "<script>" bodies to the converter without the tag that tells it
+// to drop them. Instead it's accumulated into a "loose" run that keeps
+// growing across top-level siblings until the next block element starts (or
+// EOF), at which point it's flushed as one fragment — same fidelity as
+// ConvertString, just bounded to the loose run's size instead of the whole
+// document. A document with no block-level tags at all falls back to one
+// flush for the whole input.
+func (s *StreamConverter) convertIncremental(w io.Writer, r io.Reader) error {
+	z := html.NewTokenizer(r)
+	var buf bytes.Buffer
+	depth := 0
+	inBlock := false
+
+	// wrote tracks whether a fragment has already been written to w, so the
+	// "\n\n" blank-line separator goes *before* every fragment after the
+	// first instead of after every fragment — otherwise the final fragment
+	// would trail a blank line that ConvertString never produces.
+	wrote := false
+	emit := func(fragment []byte) error {
+		md, err := s.conv.ConvertString(string(fragment))
+		if err != nil {
+			return err
+		}
+		md = strings.TrimRight(md, "\n")
+		if md == "" {
+			return nil
+		}
+		if wrote {
+			if _, err := io.WriteString(w, "\n\n"); err != nil {
+				return err
+			}
+		}
+		if _, err := io.WriteString(w, md); err != nil {
+			return err
+		}
+		wrote = true
+		return nil
+	}
+
+	flushLoose := func() error {
+		if buf.Len() == 0 {
+			return nil
+		}
+		defer buf.Reset()
+		return emit(buf.Bytes())
+	}
+
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			if err := z.Err(); err != io.EOF {
+				return err
+			}
+			break
+		}
+		raw := z.Raw()
+
+		if depth == 0 {
+			switch tt {
+			case html.StartTagToken, html.SelfClosingTagToken:
+				name, _ := z.TagName()
+				tag := string(name)
+				if blockTags[tag] {
+					if err := flushLoose(); err != nil {
+						return err
+					}
+					buf.Write(raw)
+					if tt == html.StartTagToken && !voidElements[tag] {
+						depth = 1
+						inBlock = true
+						continue
+					}
+					if err := emit(buf.Bytes()); err != nil {
+						return err
+					}
+					buf.Reset()
+					continue
+				}
+				buf.Write(raw)
+				if tt == html.StartTagToken && !voidElements[tag] {
+					depth = 1
+					inBlock = false
+				}
+			case html.TextToken, html.CommentToken:
+				if buf.Len() == 0 && len(bytes.TrimSpace(raw)) == 0 {
+					continue
+				}
+				buf.Write(raw)
+			case html.DoctypeToken:
+				// Carries no Markdown representation either way; drop it.
+			}
+			continue
+		}
+
+		buf.Write(raw)
+		switch tt {
+		case html.StartTagToken:
+			name, _ := z.TagName()
+			if !voidElements[string(name)] {
+				depth++
+			}
+		case html.EndTagToken:
+			depth--
+			if depth == 0 && inBlock {
+				if err := emit(buf.Bytes()); err != nil {
+					return err
+				}
+				buf.Reset()
+			}
+		}
+	}
+
+	return flushLoose()
+}
@@ -0,0 +1,121 @@
+package streamconv
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/base"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/commonmark"
+)
+
+func newConverter() *converter.Converter {
+	return converter.NewConverter(converter.WithPlugins(
+		base.NewBasePlugin(),
+		commonmark.NewCommonmarkPlugin(),
+	))
+}
+
+func newStreamConverter() *StreamConverter {
+	return New(newConverter(), base.NewBasePlugin(), commonmark.NewCommonmarkPlugin())
+}
+
+// TestConvertStreamMatchesConvertString checks that ConvertStream produces
+// byte-identical output to the buffered ConvertString for documents that
+// exercise the block/loose-run boundaries: multiple sibling blocks, nested
+// blocks, inline markup outside a block wrapper, and a malformed document
+// with an unclosed tag.
+func TestConvertStreamMatchesConvertString(t *testing.T) {
+	cases := []struct {
+		name string
+		html string
+	}{
+		{"two paragraphs", "<p>foo</p><p>bar</p>"},
+		{"heading and paragraph", "<h1>Title</h1><p>Body text.</p>"},
+		{"nested blockquote", "<blockquote><p>Quoted</p><p>Again</p></blockquote>"},
+		{"nested list", "<ul><li>one<ul><li>nested</li></ul></li><li>two</li></ul>"},
+		{"table", "<table><tr><th>A</th><th>B</th></tr><tr><td>1</td><td>2</td></tr></table>"},
+		{"code block", "<pre><code>x := 1\ny := 2\n</code></pre>"},
+		{"loose inline run between blocks", `<nav><a href="/">Home</a> <a href="/about">About</a></nav><p>Hi</p>`},
+		{"script and style dropped", "<script>var x = 1;</script><style>p{color:red}</style><p>Hello <b>world</b></p>"},
+		{"single block only", "<p>only one block</p>"},
+		{"malformed unclosed tag", "<p>foo"},
+		{"malformed unclosed nested tag", "<blockquote><p>foo</blockquote>"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			want, err := newConverter().ConvertString(c.html)
+			if err != nil {
+				t.Fatalf("ConvertString: %v", err)
+			}
+
+			var buf bytes.Buffer
+			if err := newStreamConverter().ConvertStream(&buf, strings.NewReader(c.html)); err != nil {
+				t.Fatalf("ConvertStream: %v", err)
+			}
+
+			if got := buf.String(); got != want {
+				t.Errorf("ConvertStream output diverges from ConvertString:\nwant: %q\ngot:  %q", want, got)
+			}
+		})
+	}
+}
+
+// fullDocPlugin is a minimal converter.Plugin used to exercise the
+// FullDocumentPlugin opt-out hook; it registers no rules of its own.
+type fullDocPlugin struct {
+	needsFull bool
+}
+
+func (p fullDocPlugin) Name() string                         { return "fulldoc-test" }
+func (p fullDocPlugin) Init(conv *converter.Converter) error { return nil }
+func (p fullDocPlugin) NeedsFullDocument() bool              { return p.needsFull }
+
+func TestNeedsFullDocument(t *testing.T) {
+	cases := []struct {
+		name    string
+		plugins []converter.Plugin
+		want    bool
+	}{
+		{"no plugins", nil, false},
+		{"plugin without the hook", []converter.Plugin{base.NewBasePlugin()}, false},
+		{"hook present but opts in to streaming", []converter.Plugin{fullDocPlugin{needsFull: false}}, false},
+		{"hook present and opts out", []converter.Plugin{base.NewBasePlugin(), fullDocPlugin{needsFull: true}}, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			sc := &StreamConverter{plugins: c.plugins}
+			if got := sc.needsFullDocument(); got != c.want {
+				t.Errorf("needsFullDocument() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+// TestConvertStreamFullDocumentFallback checks that a plugin opting out via
+// NeedsFullDocument routes ConvertStream through the buffered path and still
+// produces the same output ConvertString would.
+func TestConvertStreamFullDocumentFallback(t *testing.T) {
+	html := "<p>foo</p><p>bar</p>"
+	conv := newConverter()
+	want, err := conv.ConvertString(html)
+	if err != nil {
+		t.Fatalf("ConvertString: %v", err)
+	}
+
+	sc := New(conv, base.NewBasePlugin(), commonmark.NewCommonmarkPlugin(), fullDocPlugin{needsFull: true})
+	if !sc.needsFullDocument() {
+		t.Fatal("needsFullDocument() = false, want true")
+	}
+
+	var buf bytes.Buffer
+	if err := sc.ConvertStream(&buf, strings.NewReader(html)); err != nil {
+		t.Fatalf("ConvertStream: %v", err)
+	}
+	if got := buf.String(); got != want {
+		t.Errorf("ConvertStream (buffered fallback) = %q, want %q", got, want)
+	}
+}